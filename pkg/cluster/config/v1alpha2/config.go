@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Config holds the cluster-wide settings for a `kind` cluster
+type Config struct {
+	// TypeMeta representing the type of the object and its API schema version.
+	metav1.TypeMeta `json:",inline"`
+
+	// Nodes contains the list of nodes defined in this cluster config
+	Nodes []Node `json:"nodes,omitempty"`
+	// NodeGroups defines per-role defaults inherited by any Node in Nodes
+	// whose Role matches a key here
+	NodeGroups NodeGroups `json:"nodeGroups,omitempty"`
+}
+
+// ApplyNodeGroupDefaults returns a copy of node with any zero-valued fields
+// filled in from the NodeGroups entry matching node.Role, if one exists.
+// Fields already set on node take precedence; maps are merged key-by-key
+// with node's values winning on collision. If node.Role has no matching
+// entry in groups, node is returned unchanged.
+func ApplyNodeGroupDefaults(node Node, groups NodeGroups) Node {
+	group, ok := groups[node.Role]
+	if !ok {
+		return node
+	}
+	if node.Image == "" {
+		node.Image = group.Image
+	}
+	node.KubeletExtraArgs = mergeStringMaps(group.KubeletExtraArgs, node.KubeletExtraArgs)
+	node.Labels = mergeStringMaps(group.Labels, node.Labels)
+	if len(node.Taints) == 0 && len(group.Taints) > 0 {
+		node.Taints = append([]Taint{}, group.Taints...)
+	}
+	if len(node.ExtraMounts) == 0 && len(group.ExtraMounts) > 0 {
+		node.ExtraMounts = append([]Mount{}, group.ExtraMounts...)
+	}
+	if len(node.ExtraPortMappings) == 0 && len(group.ExtraPortMappings) > 0 {
+		node.ExtraPortMappings = append([]PortMapping{}, group.ExtraPortMappings...)
+	}
+	if group.NodeLifecycle != nil {
+		controlPlane := ControlPlane{}
+		if node.ControlPlane != nil {
+			controlPlane = *node.ControlPlane
+		}
+		if controlPlane.NodeLifecycle == nil {
+			controlPlane.NodeLifecycle = group.NodeLifecycle
+		}
+		node.ControlPlane = &controlPlane
+	}
+	return node
+}
+
+// mergeStringMaps returns a map containing base's entries overridden by
+// override's entries. Either argument may be nil.
+func mergeStringMaps(base, override map[string]string) map[string]string {
+	if len(base) == 0 {
+		return override
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
+// Validate returns a list of errors found while validating Config: that
+// every Node's Role is either well-known or has a matching NodeGroups entry,
+// plus every error Node.Validate finds for that Node. kubernetesVersion is
+// the target cluster's Kubernetes version.
+func (c *Config) Validate(kubernetesVersion string) []error {
+	errs := []error{}
+	sawBootstrapControlPlane := false
+	for i := range c.Nodes {
+		node := &c.Nodes[i]
+		if err := ValidateRole(node.Role, c.NodeGroups); err != nil {
+			errs = append(errs, err)
+		}
+		isBootstrapControlPlane := false
+		if node.Role == ControlPlaneRole && !sawBootstrapControlPlane {
+			isBootstrapControlPlane = true
+			sawBootstrapControlPlane = true
+		}
+		errs = append(errs, node.Validate(kubernetesVersion, isBootstrapControlPlane)...)
+	}
+	return errs
+}
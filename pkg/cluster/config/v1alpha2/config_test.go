@@ -0,0 +1,142 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyNodeGroupDefaults(t *testing.T) {
+	groups := NodeGroups{
+		WorkerGPURole: NodeGroup{
+			Image:             "kindest/node:gpu",
+			KubeletExtraArgs:  map[string]string{"feature-gates": "x=true"},
+			Labels:            map[string]string{"nvidia.com/gpu": "true"},
+			Taints:            []Taint{{Key: "nvidia.com/gpu", Effect: "NoSchedule"}},
+			ExtraMounts:       []Mount{{ContainerPath: "/var/lib/nvidia", HostPath: "/var/lib/nvidia"}},
+			ExtraPortMappings: []PortMapping{{ContainerPort: 8080, HostPort: 8080}},
+		},
+	}
+
+	t.Run("unmatched role is unchanged", func(t *testing.T) {
+		node := Node{Role: WorkerRole}
+		got := ApplyNodeGroupDefaults(node, groups)
+		if !reflect.DeepEqual(got, node) {
+			t.Errorf("expected node unchanged, got %+v", got)
+		}
+	})
+
+	t.Run("empty fields inherit group defaults", func(t *testing.T) {
+		node := Node{Role: WorkerGPURole}
+		got := ApplyNodeGroupDefaults(node, groups)
+		if got.Image != "kindest/node:gpu" {
+			t.Errorf("expected image inherited, got %q", got.Image)
+		}
+		if got.Labels["nvidia.com/gpu"] != "true" {
+			t.Errorf("expected label inherited, got %+v", got.Labels)
+		}
+		if len(got.Taints) != 1 || got.Taints[0].Key != "nvidia.com/gpu" {
+			t.Errorf("expected taint inherited, got %+v", got.Taints)
+		}
+		if len(got.ExtraMounts) != 1 || got.ExtraMounts[0].HostPath != "/var/lib/nvidia" {
+			t.Errorf("expected extraMounts inherited, got %+v", got.ExtraMounts)
+		}
+		if len(got.ExtraPortMappings) != 1 || got.ExtraPortMappings[0].ContainerPort != 8080 {
+			t.Errorf("expected extraPortMappings inherited, got %+v", got.ExtraPortMappings)
+		}
+	})
+
+	t.Run("inherited slices are copies, not aliases of the group's", func(t *testing.T) {
+		node := Node{Role: WorkerGPURole}
+		got := ApplyNodeGroupDefaults(node, groups)
+		got.Taints[0].Value = "mutated"
+		got.ExtraMounts[0].HostPath = "/mutated"
+		if groups[WorkerGPURole].Taints[0].Value == "mutated" {
+			t.Errorf("mutating the returned node's Taints mutated the shared group defaults")
+		}
+		if groups[WorkerGPURole].ExtraMounts[0].HostPath == "/mutated" {
+			t.Errorf("mutating the returned node's ExtraMounts mutated the shared group defaults")
+		}
+	})
+
+	t.Run("set fields take precedence over group defaults", func(t *testing.T) {
+		node := Node{
+			Role:  WorkerGPURole,
+			Image: "custom/image:latest",
+			Labels: map[string]string{
+				"nvidia.com/gpu": "false",
+				"zone":           "us-east-1a",
+			},
+		}
+		got := ApplyNodeGroupDefaults(node, groups)
+		if got.Image != "custom/image:latest" {
+			t.Errorf("expected node image to win, got %q", got.Image)
+		}
+		if got.Labels["nvidia.com/gpu"] != "false" || got.Labels["zone"] != "us-east-1a" {
+			t.Errorf("expected node labels to win/merge, got %+v", got.Labels)
+		}
+	})
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := &Config{
+		Nodes: []Node{
+			{Role: ControlPlaneRole},
+			{Role: WorkerGPURole},
+		},
+		NodeGroups: NodeGroups{
+			WorkerGPURole: NodeGroup{},
+		},
+	}
+	if errs := cfg.Validate("v1.24.0"); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+
+	cfg.Nodes = append(cfg.Nodes, Node{Role: "bogus"})
+	if errs := cfg.Validate("v1.24.0"); len(errs) != 1 {
+		t.Errorf("expected 1 error for the unknown role, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestConfigValidateAggregatesNodeErrors(t *testing.T) {
+	cfg := &Config{
+		Nodes: []Node{
+			{
+				Role: ControlPlaneRole,
+				Files: []FileSource{
+					{Path: "/x", Content: "a", ContentFrom: &FileSourceRef{URL: "https://example.com"}},
+				},
+				KubeadmConfig: &KubeadmConfig{
+					Networking: &Networking{PodSubnet: "not-a-cidr"},
+				},
+				ControlPlane: &ControlPlane{
+					NodeLifecycle: &NodeLifecycle{
+						PreBoot: []LifecycleHook{{Command: []string{"true"}, Retries: -1}},
+					},
+					Join: &JoinControlPlane{SkipPhases: []string{"not-a-real-phase"}},
+				},
+			},
+		},
+	}
+	errs := cfg.Validate("v1.24.0")
+	// content+contentFrom, invalid podSubnet, negative retries, join on the
+	// bootstrap control-plane node, and an unknown skip phase
+	if len(errs) != 5 {
+		t.Fatalf("expected 5 aggregated errors, got %d: %v", len(errs), errs)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import "testing"
+
+func TestJoinControlPlaneValidate(t *testing.T) {
+	cases := []struct {
+		name                    string
+		join                    *JoinControlPlane
+		role                    NodeRole
+		isBootstrapControlPlane bool
+		expectErrs              int
+	}{
+		{
+			name:       "nil join",
+			join:       nil,
+			role:       ControlPlaneRole,
+			expectErrs: 0,
+		},
+		{
+			name:       "valid join on a non-bootstrap control-plane node",
+			join:       &JoinControlPlane{SkipPhases: []string{"mark-control-plane"}},
+			role:       ControlPlaneRole,
+			expectErrs: 0,
+		},
+		{
+			name:                    "join on the bootstrap control-plane node",
+			join:                    &JoinControlPlane{},
+			role:                    ControlPlaneRole,
+			isBootstrapControlPlane: true,
+			expectErrs:              1,
+		},
+		{
+			name:       "join on a worker node",
+			join:       &JoinControlPlane{},
+			role:       WorkerRole,
+			expectErrs: 1,
+		},
+		{
+			name:       "unknown skip phase",
+			join:       &JoinControlPlane{SkipPhases: []string{"bogus-phase"}},
+			role:       ControlPlaneRole,
+			expectErrs: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := tc.join.Validate(tc.role, tc.isBootstrapControlPlane)
+			if len(errs) != tc.expectErrs {
+				t.Errorf("expected %d errors, got %d: %v", tc.expectErrs, len(errs), errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLifecycleHookRenderEnv(t *testing.T) {
+	h := &LifecycleHook{
+		Env: map[string]string{
+			"NODE_NAME": "{{.NodeName}}",
+			"ROLE":      "{{.Role}}",
+		},
+	}
+	rendered, err := h.RenderEnv(HookTemplateData{NodeName: "kind-worker", Role: WorkerRole})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rendered["NODE_NAME"] != "kind-worker" || rendered["ROLE"] != "worker" {
+		t.Errorf("unexpected rendered env: %+v", rendered)
+	}
+
+	bad := &LifecycleHook{Env: map[string]string{"X": "{{.NoSuchField}}"}}
+	if _, err := bad.RenderEnv(HookTemplateData{}); err == nil {
+		t.Errorf("expected an error for a template referencing an unknown field")
+	}
+}
+
+func TestLifecycleHookValidate(t *testing.T) {
+	cases := []struct {
+		name       string
+		hook       LifecycleHook
+		expectErrs int
+	}{
+		{
+			name:       "defaults are valid",
+			hook:       LifecycleHook{Command: []string{"true"}},
+			expectErrs: 0,
+		},
+		{
+			name:       "negative timeout",
+			hook:       LifecycleHook{Command: []string{"true"}, Timeout: metav1.Duration{Duration: -1}},
+			expectErrs: 1,
+		},
+		{
+			name:       "negative retryDelay",
+			hook:       LifecycleHook{Command: []string{"true"}, RetryDelay: metav1.Duration{Duration: -1}},
+			expectErrs: 1,
+		},
+		{
+			name:       "negative retries",
+			hook:       LifecycleHook{Command: []string{"true"}, Retries: -1},
+			expectErrs: 1,
+		},
+		{
+			name:       "malformed env template",
+			hook:       LifecycleHook{Command: []string{"true"}, Env: map[string]string{"X": "{{"}},
+			expectErrs: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := tc.hook.Validate()
+			if len(errs) != tc.expectErrs {
+				t.Errorf("expected %d errors, got %d: %v", tc.expectErrs, len(errs), errs)
+			}
+		})
+	}
+}
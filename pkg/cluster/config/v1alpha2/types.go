@@ -49,9 +49,181 @@ type Node struct {
 	KubeadmConfigPatchesJSON6902 []kustomize.PatchJSON6902 `json:"kubeadmConfigPatchesJson6902,omitempty"`
 	// ControlPlane holds config for the control plane node
 	ControlPlane *ControlPlane `json:"ControlPlane,omitempty"`
+	// Labels are the labels with which the respective node will be registered
+	// in the Kubernetes cluster
+	Labels map[string]string `json:"labels,omitempty"`
+	// Taints are the taints with which the respective node will be registered
+	// in the Kubernetes cluster
+	Taints []Taint `json:"taints,omitempty"`
+	// KubeletExtraArgs are extra arguments passed to the kubelet running on
+	// this node, in addition to the arguments `kind` sets by default
+	KubeletExtraArgs map[string]string `json:"kubeletExtraArgs,omitempty"`
+	// KubeadmConfig holds typed overrides for the generated kubeadm config.
+	// These are merged into the ClusterConfiguration / InitConfiguration /
+	// JoinConfiguration produced by `kind` before KubeadmConfigPatches and
+	// KubeadmConfigPatchesJSON6902 are applied, so that common knobs do not
+	// require hand-authored patch YAML
+	KubeadmConfig *KubeadmConfig `json:"kubeadmConfig,omitempty"`
+	// Files are written to the node before the PreKubeadm lifecycle phase runs
+	Files []FileSource `json:"files,omitempty"`
+	// ExtraMounts are extra volume mounts to be mounted into the node container
+	ExtraMounts []Mount `json:"extraMounts,omitempty"`
+	// ExtraPortMappings are extra port mappings to be forwarded from the host
+	// into the node container
+	ExtraPortMappings []PortMapping `json:"extraPortMappings,omitempty"`
 }
 
-// NodeRole defines possible role for nodes in a Kubernetes cluster managed by `kind`
+// Mount specifies a host volume to mount into a node container, mounting
+// into the container's filesystem
+type Mount struct {
+	// ContainerPath is the path inside the node container at which the
+	// volume should be mounted
+	ContainerPath string `json:"containerPath,omitempty"`
+	// HostPath is the path on the host to mount from
+	HostPath string `json:"hostPath,omitempty"`
+	// Readonly specifies if the mount should be read-only
+	Readonly bool `json:"readOnly,omitempty"`
+	// Propagation specifies how mounts are propagated from the host to the
+	// container and vice versa
+	Propagation MountPropagation `json:"propagation,omitempty"`
+}
+
+// MountPropagation represents a mount propagation value, see
+// https://kubernetes.io/docs/concepts/storage/volumes/#mount-propagation
+type MountPropagation string
+
+const (
+	// MountPropagationNone specifies that no mount propagation is used
+	MountPropagationNone MountPropagation = "None"
+	// MountPropagationHostToContainer specifies that host volume mounts are
+	// propagated to the container, but not vice versa
+	MountPropagationHostToContainer MountPropagation = "HostToContainer"
+	// MountPropagationBidirectional specifies that volume mounts are
+	// propagated in both directions
+	MountPropagationBidirectional MountPropagation = "Bidirectional"
+)
+
+// PortMapping specifies a host port mapped into a node container
+type PortMapping struct {
+	// ContainerPort is the port inside the node container
+	ContainerPort int32 `json:"containerPort,omitempty"`
+	// HostPort is the port on the host to bind to.
+	// Defaults to a random available port on the host if unset
+	HostPort int32 `json:"hostPort,omitempty"`
+	// ListenAddress is the host address the port should be bound on.
+	// Defaults to "0.0.0.0" if unset
+	ListenAddress string `json:"listenAddress,omitempty"`
+	// Protocol is the protocol to forward, one of "TCP", "UDP", or "SCTP".
+	// Defaults to "TCP" if unset
+	Protocol PortMappingProtocol `json:"protocol,omitempty"`
+}
+
+// PortMappingProtocol specifies the protocol used for a PortMapping
+type PortMappingProtocol string
+
+const (
+	// PortMappingProtocolTCP specifies TCP protocol
+	PortMappingProtocolTCP PortMappingProtocol = "TCP"
+	// PortMappingProtocolUDP specifies UDP protocol
+	PortMappingProtocolUDP PortMappingProtocol = "UDP"
+	// PortMappingProtocolSCTP specifies SCTP protocol
+	PortMappingProtocolSCTP PortMappingProtocol = "SCTP"
+)
+
+// FileSource represents a file to be materialized on a node before the
+// PreKubeadm lifecycle phase runs, mirroring the `files` semantics of
+// cloud-init based kubeadm bootstrap providers
+type FileSource struct {
+	// Path is the absolute path, inside the node, the file should be written to
+	Path string `json:"path"`
+	// Owner is the "user:group" the file should be owned by (optional)
+	Owner string `json:"owner,omitempty"`
+	// Permissions is the octal file mode, e.g. "0644" (optional, defaults to "0644")
+	Permissions string `json:"permissions,omitempty"`
+	// Content is the literal content to write to Path.
+	// Exactly one of Content or ContentFrom must be set
+	Content string `json:"content,omitempty"`
+	// ContentFrom is a reference to content to write to Path, as a path on
+	// the host, a URL, or a configMap-like "namespace/name/key" reference.
+	// Exactly one of Content or ContentFrom must be set
+	ContentFrom *FileSourceRef `json:"contentFrom,omitempty"`
+	// Encoding is the encoding Content (or the resolved ContentFrom) is in.
+	// One of "plain" (default), "base64", or "gzip+base64"
+	Encoding FileEncoding `json:"encoding,omitempty"`
+}
+
+// FileSourceRef is a reference to file content stored outside of the config
+type FileSourceRef struct {
+	// Path is a path on the host to read the file content from
+	Path string `json:"path,omitempty"`
+	// URL is a URL to fetch the file content from
+	URL string `json:"url,omitempty"`
+	// ConfigMapKeyRef refers to a key in a ConfigMap as "namespace/name/key"
+	ConfigMapKeyRef string `json:"configMapKeyRef,omitempty"`
+}
+
+// FileEncoding specifies the encoding used for FileSource content
+type FileEncoding string
+
+const (
+	// PlainFileEncoding is the default, unencoded file content
+	PlainFileEncoding FileEncoding = "plain"
+	// Base64FileEncoding is base64 encoded file content
+	Base64FileEncoding FileEncoding = "base64"
+	// GzipBase64FileEncoding is gzip compressed then base64 encoded file content
+	GzipBase64FileEncoding FileEncoding = "gzip+base64"
+)
+
+// KubeadmConfig holds typed overrides for the common fields users otherwise
+// have to patch via KubeadmConfigPatches / KubeadmConfigPatchesJSON6902
+type KubeadmConfig struct {
+	// FeatureGates is merged into the featureGates of the generated
+	// ClusterConfiguration
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// RuntimeConfig is merged into apiServer.extraArgs["runtime-config"]
+	// handling of the generated ClusterConfiguration
+	RuntimeConfig map[string]string `json:"runtimeConfig,omitempty"`
+	// APIServerExtraArgs is merged into apiServer.extraArgs
+	APIServerExtraArgs map[string]string `json:"apiServerExtraArgs,omitempty"`
+	// ControllerManagerExtraArgs is merged into controllerManager.extraArgs
+	ControllerManagerExtraArgs map[string]string `json:"controllerManagerExtraArgs,omitempty"`
+	// SchedulerExtraArgs is merged into scheduler.extraArgs
+	SchedulerExtraArgs map[string]string `json:"schedulerExtraArgs,omitempty"`
+	// EtcdExtraArgs is merged into etcd.local.extraArgs
+	EtcdExtraArgs map[string]string `json:"etcdExtraArgs,omitempty"`
+	// Networking holds overrides for the generated ClusterConfiguration.Networking
+	Networking *Networking `json:"networking,omitempty"`
+	// CertSANs is merged into apiServer.certSANs
+	CertSANs []string `json:"certSANs,omitempty"`
+}
+
+// Networking holds typed overrides for the generated
+// ClusterConfiguration.Networking
+type Networking struct {
+	// PodSubnet overrides the default pod subnet
+	PodSubnet string `json:"podSubnet,omitempty"`
+	// ServiceSubnet overrides the default service subnet
+	ServiceSubnet string `json:"serviceSubnet,omitempty"`
+	// DNSDomain overrides the default cluster DNS domain
+	DNSDomain string `json:"dnsDomain,omitempty"`
+}
+
+// Taint represents a Kubernetes taint applied to a node
+type Taint struct {
+	// Key is the taint key to be applied to a node
+	Key string `json:"key"`
+	// Value is the taint value corresponding to the taint key
+	Value string `json:"value,omitempty"`
+	// Effect is the effect of the taint on pods that do not tolerate it,
+	// e.g. NoSchedule, PreferNoSchedule or NoExecute
+	Effect string `json:"effect"`
+}
+
+// NodeRole defines possible role for nodes in a Kubernetes cluster managed by `kind`.
+// ControlPlaneRole, WorkerRole, ExternalEtcdRole and ExternalLoadBalancerRole
+// are always accepted; any other role, including WorkerGPURole and
+// StorageRole, is accepted only when a matching entry exists in the
+// cluster's NodeGroups (see ValidateRole)
 type NodeRole string
 
 const (
@@ -66,13 +238,121 @@ const (
 	// in HA configurations.
 	// Please note that `kind` nodes hosting external load balancer are not kubernetes nodes
 	ExternalLoadBalancerRole NodeRole = "external-load-balancer"
+	// WorkerGPURole identifies a node that hosts a Kubernetes worker intended
+	// for GPU workloads. This is a well-known alias for a NodeGroups entry;
+	// it carries no special meaning unless a matching NodeGroups entry exists
+	WorkerGPURole NodeRole = "worker-gpu"
+	// StorageRole identifies a node that hosts a Kubernetes worker intended
+	// for storage workloads. This is a well-known alias for a NodeGroups
+	// entry; it carries no special meaning unless a matching NodeGroups
+	// entry exists
+	StorageRole NodeRole = "storage"
 )
 
+// NodeGroup holds the defaults applied to every Node whose Role matches the
+// NodeGroups key it is registered under. A Node only needs to set Role (and
+// optionally Replicas) to inherit these defaults, which keeps configs for
+// heterogeneous multi-node clusters small. NodeGroups is set on the
+// cluster-level Config alongside Nodes, keyed by NodeRole; see
+// ApplyNodeGroupDefaults for how a Node inherits a group's defaults.
+//
+// Validation rejects a Node using a Role that is neither one of the built-in
+// roles nor a key in NodeGroups; see Config.Validate.
+type NodeGroup struct {
+	// Image is the node image to use for nodes in this group
+	Image string `json:"image,omitempty"`
+	// ExtraMounts are extra volume mounts applied to nodes in this group
+	ExtraMounts []Mount `json:"extraMounts,omitempty"`
+	// ExtraPortMappings are extra port mappings applied to nodes in this group
+	ExtraPortMappings []PortMapping `json:"extraPortMappings,omitempty"`
+	// KubeletExtraArgs are extra kubelet arguments applied to nodes in this group
+	KubeletExtraArgs map[string]string `json:"kubeletExtraArgs,omitempty"`
+	// Labels are the labels applied to nodes in this group
+	Labels map[string]string `json:"labels,omitempty"`
+	// Taints are the taints applied to nodes in this group
+	Taints []Taint `json:"taints,omitempty"`
+	// NodeLifecycle contains the LifecycleHooks applied to nodes in this group
+	NodeLifecycle *NodeLifecycle `json:"nodeLifecycle,omitempty"`
+}
+
+// NodeGroups maps a NodeRole to the NodeGroup defaults for nodes of that role
+type NodeGroups map[NodeRole]NodeGroup
+
+// Well-known label and taint keys applied to control-plane nodes.
+// Both the legacy and the current key are emitted for every control-plane
+// node so that clusters work against Kubernetes releases before and after
+// 1.24, which dropped NodeRoleLabelKeyLegacy in favor of NodeRoleLabelKey.
+const (
+	// NodeRoleLabelKeyLegacy is the node-role label kubeadm used to apply to
+	// control-plane nodes prior to Kubernetes 1.24
+	NodeRoleLabelKeyLegacy = "node-role.kubernetes.io/master"
+	// NodeRoleLabelKey is the node-role label kubeadm applies to control-plane
+	// nodes as of Kubernetes 1.24
+	NodeRoleLabelKey = "node-role.kubernetes.io/control-plane"
+)
+
+// ControlPlaneLabels returns the labels a control-plane node should be
+// registered with: extra (typically the Node's own Labels) plus the dual
+// node-role labels so the node is recognized as a control-plane node on
+// Kubernetes releases both before and after 1.24. extra takes precedence
+// over the well-known labels if the keys collide.
+func ControlPlaneLabels(extra map[string]string) map[string]string {
+	labels := map[string]string{
+		NodeRoleLabelKeyLegacy: "",
+		NodeRoleLabelKey:       "",
+	}
+	for k, v := range extra {
+		labels[k] = v
+	}
+	return labels
+}
+
+// ControlPlaneTaints returns the taints a control-plane node should be
+// registered with: the dual node-role taints (so the node is recognized as a
+// control-plane node on Kubernetes releases both before and after 1.24)
+// followed by extra (typically the Node's own Taints).
+func ControlPlaneTaints(extra []Taint) []Taint {
+	taints := []Taint{
+		{Key: NodeRoleLabelKeyLegacy, Effect: "NoSchedule"},
+		{Key: NodeRoleLabelKey, Effect: "NoSchedule"},
+	}
+	return append(taints, extra...)
+}
+
 // ControlPlane holds configurations specific to the control plane nodes
 // (currently the only node).
 type ControlPlane struct {
 	// NodeLifecycle contains LifecycleHooks for phases of node provisioning
 	NodeLifecycle *NodeLifecycle `json:"nodeLifecycle,omitempty"`
+	// Join holds options for how this node joins an existing control plane,
+	// mirroring kubeadm's JoinConfiguration.controlPlane. It is only
+	// meaningful for control-plane nodes joining an HA cluster after the
+	// first control-plane node has been created
+	Join *JoinControlPlane `json:"join,omitempty"`
+}
+
+// JoinControlPlane mirrors kubeadm's JoinConfiguration.controlPlane, letting
+// users control how additional control-plane nodes join an HA cluster
+// without forking KubeadmConfigPatches
+type JoinControlPlane struct {
+	// CertificateKey is the key used to decrypt certificates downloaded from
+	// the cluster when joining a new control-plane node
+	CertificateKey string `json:"certificateKey,omitempty"`
+	// LocalAPIEndpoint is the endpoint advertised by this control-plane node
+	// for the kube-apiserver
+	LocalAPIEndpoint *APIEndpoint `json:"localAPIEndpoint,omitempty"`
+	// SkipPhases is a list of join phases to skip, e.g. "mark-control-plane"
+	SkipPhases []string `json:"skipPhases,omitempty"`
+}
+
+// APIEndpoint is the endpoint advertised by a control-plane node for the
+// kube-apiserver
+type APIEndpoint struct {
+	// AdvertiseAddress is the IP address the API server advertises it is
+	// accessible on
+	AdvertiseAddress string `json:"advertiseAddress,omitempty"`
+	// BindPort is the port the API server binds to
+	BindPort int32 `json:"bindPort,omitempty"`
 }
 
 // NodeLifecycle contains LifecycleHooks for phases of node provisioning
@@ -98,4 +378,23 @@ type LifecycleHook struct {
 	// cluster creation to fail, otherwise the error will just be logged and
 	// the boot process will continue
 	MustSucceed bool `json:"mustSucceed,omitempty"`
+	// Timeout is the maximum time to let a single attempt of Command run
+	// before it is canceled, intended to be enforced by the provisioner via
+	// context cancellation. Defaults to no timeout. See Validate for the
+	// static check applied to this field here
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// Retries is the number of additional times to retry Command after it
+	// fails, before giving up. Defaults to 0 (no retries)
+	Retries int `json:"retries,omitempty"`
+	// RetryDelay is how long to wait between retries. Defaults to 0
+	RetryDelay metav1.Duration `json:"retryDelay,omitempty"`
+	// Env are extra environment variables to set for Command, in addition to
+	// the ones `kind` sets by default. Values may reference cluster/node
+	// metadata via Go template syntax, e.g. "{{.NodeName}}", "{{.Role}}", and
+	// "{{.KubernetesVersion}}"
+	Env map[string]string `json:"env,omitempty"`
+	// WorkingDir sets the working directory Command is run from (optional)
+	WorkingDir string `json:"workingDir,omitempty"`
+	// Stdin is piped to Command on standard input (optional)
+	Stdin string `json:"stdin,omitempty"`
 }
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestControlPlaneLabels(t *testing.T) {
+	cases := []struct {
+		name   string
+		extra  map[string]string
+		expect map[string]string
+	}{
+		{
+			name:  "nil extra still emits both keys",
+			extra: nil,
+			expect: map[string]string{
+				NodeRoleLabelKeyLegacy: "",
+				NodeRoleLabelKey:       "",
+			},
+		},
+		{
+			name:  "extra labels are merged in",
+			extra: map[string]string{"disktype": "ssd"},
+			expect: map[string]string{
+				NodeRoleLabelKeyLegacy: "",
+				NodeRoleLabelKey:       "",
+				"disktype":             "ssd",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ControlPlaneLabels(tc.extra)
+			if !reflect.DeepEqual(got, tc.expect) {
+				t.Errorf("got %v, want %v", got, tc.expect)
+			}
+		})
+	}
+}
+
+func TestControlPlaneTaints(t *testing.T) {
+	extra := []Taint{{Key: "dedicated", Value: "control-plane", Effect: "NoSchedule"}}
+	got := ControlPlaneTaints(extra)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 taints, got %d: %+v", len(got), got)
+	}
+	if got[0].Key != NodeRoleLabelKeyLegacy || got[1].Key != NodeRoleLabelKey {
+		t.Errorf("expected the dual node-role taints first, got %+v", got[:2])
+	}
+	if got[2] != extra[0] {
+		t.Errorf("expected extra taints to be appended, got %+v", got[2])
+	}
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"text/template"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// removedFeatureGates maps a feature gate name that has graduated to GA (and
+// was therefore removed from kubeadm/kubernetes) to the Kubernetes version it
+// was removed in. A gate not in this map is assumed to still be a valid,
+// unrecognized-by-kind gate name and is passed through to kubeadm unchanged;
+// only gates known to no longer exist on the target version are rejected.
+var removedFeatureGates = map[string]string{
+	"TTLAfterFinished":    "1.21",
+	"IPv6DualStack":       "1.23",
+	"EphemeralContainers": "1.25",
+}
+
+// Validate returns a list of errors found while validating KubeadmConfig.
+// kubernetesVersion is the target cluster's Kubernetes version and is used
+// to report actionable errors for feature gates that no longer exist on
+// that version.
+func (c *KubeadmConfig) Validate(kubernetesVersion string) []error {
+	if c == nil {
+		return nil
+	}
+	errs := []error{}
+	for gate := range c.FeatureGates {
+		removedIn, known := removedFeatureGates[gate]
+		if known && versionAtLeast(kubernetesVersion, removedIn) {
+			errs = append(errs, fmt.Errorf(
+				"feature gate %q was removed in kubernetes %s, target version is %q",
+				gate, removedIn, kubernetesVersion,
+			))
+		}
+	}
+	if c.Networking != nil {
+		if c.Networking.PodSubnet != "" {
+			if _, _, err := net.ParseCIDR(c.Networking.PodSubnet); err != nil {
+				errs = append(errs, fmt.Errorf("invalid podSubnet: %v", err))
+			}
+		}
+		if c.Networking.ServiceSubnet != "" {
+			if _, _, err := net.ParseCIDR(c.Networking.ServiceSubnet); err != nil {
+				errs = append(errs, fmt.Errorf("invalid serviceSubnet: %v", err))
+			}
+		}
+	}
+	return errs
+}
+
+// versionAtLeast reports whether version is greater than or equal to min,
+// comparing only the major.minor components (e.g. "1.24.1" and "v1.24" are
+// both treated as 1.24). Unparseable input is treated as not satisfying min,
+// so KubeadmConfig.Validate does not reject a removed feature gate when the
+// target version cannot be parsed.
+func versionAtLeast(version, min string) bool {
+	vMaj, vMin, err := parseMajorMinor(version)
+	if err != nil {
+		return false
+	}
+	mMaj, mMin, err := parseMajorMinor(min)
+	if err != nil {
+		return false
+	}
+	if vMaj != mMaj {
+		return vMaj > mMaj
+	}
+	return vMin >= mMin
+}
+
+func parseMajorMinor(version string) (int, int, error) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("invalid version %q", version)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid version %q: %v", version, err)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid version %q: %v", version, err)
+	}
+	return major, minor, nil
+}
+
+// ToInternalKubeadmConfig is the v1alpha2->internal conversion entry point
+// for Node.KubeadmConfig: it validates the typed overrides against
+// kubernetesVersion and returns them ready to be merged into the generated
+// ClusterConfiguration/InitConfiguration/JoinConfiguration, before
+// KubeadmConfigPatches/KubeadmConfigPatchesJSON6902 are applied. A nil
+// KubeadmConfig converts to a nil result with no error.
+func (n *Node) ToInternalKubeadmConfig(kubernetesVersion string) (*KubeadmConfig, error) {
+	if n.KubeadmConfig == nil {
+		return nil, nil
+	}
+	if errs := n.KubeadmConfig.Validate(kubernetesVersion); len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+	return n.KubeadmConfig, nil
+}
+
+// knownFileEncodings is the set of valid FileEncoding values, plus "" which
+// is equivalent to PlainFileEncoding
+var knownFileEncodings = map[FileEncoding]bool{
+	"":                     true,
+	PlainFileEncoding:      true,
+	Base64FileEncoding:     true,
+	GzipBase64FileEncoding: true,
+}
+
+// Validate checks that f has exactly one of Content or ContentFrom set and
+// that Encoding, if set, is a recognized FileEncoding
+func (f *FileSource) Validate() []error {
+	errs := []error{}
+	if f.Path == "" {
+		errs = append(errs, fmt.Errorf("path must be set"))
+	}
+	if (f.Content != "") == (f.ContentFrom != nil) {
+		errs = append(errs, fmt.Errorf("exactly one of content or contentFrom must be set for path %q", f.Path))
+	}
+	if !knownFileEncodings[f.Encoding] {
+		errs = append(errs, fmt.Errorf("unknown encoding %q for path %q", f.Encoding, f.Path))
+	}
+	return errs
+}
+
+// HookTemplateData is the data made available to LifecycleHook.Env values
+// via Go template syntax, e.g. "{{.NodeName}}"
+type HookTemplateData struct {
+	// NodeName is the name of the node the hook is running on
+	NodeName string
+	// Role is the Role of the node the hook is running on
+	Role NodeRole
+	// KubernetesVersion is the Kubernetes version of the cluster the node
+	// belongs to
+	KubernetesVersion string
+}
+
+// RenderEnv renders each value in h.Env as a Go template against data,
+// returning the resolved environment the provisioner should set for Command.
+// A nil/empty Env renders to a nil map.
+func (h *LifecycleHook) RenderEnv(data HookTemplateData) (map[string]string, error) {
+	if len(h.Env) == 0 {
+		return nil, nil
+	}
+	rendered := make(map[string]string, len(h.Env))
+	for k, v := range h.Env {
+		tmpl, err := template.New(k).Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("env %q: %v", k, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("env %q: %v", k, err)
+		}
+		rendered[k] = buf.String()
+	}
+	return rendered, nil
+}
+
+// Validate returns a list of errors found while validating LifecycleHook,
+// including that Timeout/RetryDelay/Retries are non-negative and that Env
+// values are well-formed templates
+func (h *LifecycleHook) Validate() []error {
+	errs := []error{}
+	if h.Timeout.Duration < 0 {
+		errs = append(errs, fmt.Errorf("timeout must not be negative"))
+	}
+	if h.RetryDelay.Duration < 0 {
+		errs = append(errs, fmt.Errorf("retryDelay must not be negative"))
+	}
+	if h.Retries < 0 {
+		errs = append(errs, fmt.Errorf("retries must not be negative"))
+	}
+	if _, err := h.RenderEnv(HookTemplateData{}); err != nil {
+		errs = append(errs, fmt.Errorf("env: %v", err))
+	}
+	return errs
+}
+
+// knownJoinSkipPhases is the set of kubeadm join phase names that may appear
+// in JoinControlPlane.SkipPhases, per `kubeadm join phase list`
+var knownJoinSkipPhases = map[string]bool{
+	"preflight":             true,
+	"control-plane-prepare": true,
+	"control-plane-join":    true,
+	"kubelet-start":         true,
+	"etcd":                  true,
+	"mark-control-plane":    true,
+	"update-status":         true,
+}
+
+// Validate returns a list of errors found while validating JoinControlPlane.
+// role is the Role of the Node this ControlPlane belongs to, and
+// isBootstrapControlPlane indicates whether this is the first control-plane
+// node created for the cluster: Join is only meaningful for a control-plane
+// node joining an already-initialized control plane.
+func (j *JoinControlPlane) Validate(role NodeRole, isBootstrapControlPlane bool) []error {
+	if j == nil {
+		return nil
+	}
+	errs := []error{}
+	if role != ControlPlaneRole {
+		errs = append(errs, fmt.Errorf("join is only meaningful for %q nodes, not %q", ControlPlaneRole, role))
+	}
+	if isBootstrapControlPlane {
+		errs = append(errs, fmt.Errorf("join is not meaningful for the bootstrap control-plane node"))
+	}
+	for _, phase := range j.SkipPhases {
+		if !knownJoinSkipPhases[phase] {
+			errs = append(errs, fmt.Errorf("unknown join phase %q in skipPhases", phase))
+		}
+	}
+	return errs
+}
+
+// wellKnownRoles are the built-in NodeRole values that are always permitted,
+// independent of NodeGroups
+var wellKnownRoles = map[NodeRole]bool{
+	ControlPlaneRole:         true,
+	WorkerRole:               true,
+	ExternalEtcdRole:         true,
+	ExternalLoadBalancerRole: true,
+}
+
+// ValidateRole checks that role is either a well-known NodeRole or has a
+// matching entry in groups, returning an actionable error otherwise
+func ValidateRole(role NodeRole, groups NodeGroups) error {
+	if wellKnownRoles[role] {
+		return nil
+	}
+	if _, ok := groups[role]; ok {
+		return nil
+	}
+	return fmt.Errorf("unknown role %q: add a NodeGroups entry for this role or use a well-known role", role)
+}
+
+// Validate returns a list of errors found while validating Node, aggregating
+// errors from KubeadmConfig, Files, the lifecycle hooks under ControlPlane,
+// and ControlPlane.Join. kubernetesVersion is the target cluster's
+// Kubernetes version; isBootstrapControlPlane indicates whether this Node is
+// the first control-plane node created for the cluster.
+func (n *Node) Validate(kubernetesVersion string, isBootstrapControlPlane bool) []error {
+	errs := []error{}
+	if _, err := n.ToInternalKubeadmConfig(kubernetesVersion); err != nil {
+		errs = append(errs, err)
+	}
+	for i := range n.Files {
+		errs = append(errs, n.Files[i].Validate()...)
+	}
+	if n.ControlPlane != nil {
+		if n.ControlPlane.NodeLifecycle != nil {
+			for _, hooks := range [][]LifecycleHook{
+				n.ControlPlane.NodeLifecycle.PreBoot,
+				n.ControlPlane.NodeLifecycle.PreKubeadm,
+				n.ControlPlane.NodeLifecycle.PostKubeadm,
+				n.ControlPlane.NodeLifecycle.PostSetup,
+			} {
+				for i := range hooks {
+					errs = append(errs, hooks[i].Validate()...)
+				}
+			}
+		}
+		errs = append(errs, n.ControlPlane.Join.Validate(n.Role, isBootstrapControlPlane)...)
+	}
+	return errs
+}
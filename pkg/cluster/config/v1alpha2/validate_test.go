@@ -0,0 +1,180 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import "testing"
+
+func TestKubeadmConfigValidate(t *testing.T) {
+	cases := []struct {
+		name              string
+		config            *KubeadmConfig
+		kubernetesVersion string
+		expectErrs        int
+	}{
+		{
+			name:              "nil config",
+			config:            nil,
+			kubernetesVersion: "v1.24.0",
+			expectErrs:        0,
+		},
+		{
+			name: "unrecognized gate name is passed through",
+			config: &KubeadmConfig{
+				FeatureGates: map[string]bool{"SomeFutureGate": true},
+			},
+			kubernetesVersion: "v1.24.0",
+			expectErrs:        0,
+		},
+		{
+			name: "removed gate before its removal version is allowed",
+			config: &KubeadmConfig{
+				FeatureGates: map[string]bool{"TTLAfterFinished": true},
+			},
+			kubernetesVersion: "v1.20.0",
+			expectErrs:        0,
+		},
+		{
+			name: "removed gate at/after its removal version is rejected",
+			config: &KubeadmConfig{
+				FeatureGates: map[string]bool{"TTLAfterFinished": true},
+			},
+			kubernetesVersion: "v1.21.0",
+			expectErrs:        1,
+		},
+		{
+			name: "invalid podSubnet",
+			config: &KubeadmConfig{
+				Networking: &Networking{PodSubnet: "not-a-cidr"},
+			},
+			kubernetesVersion: "v1.24.0",
+			expectErrs:        1,
+		},
+		{
+			name: "valid networking",
+			config: &KubeadmConfig{
+				Networking: &Networking{PodSubnet: "10.244.0.0/16", ServiceSubnet: "10.96.0.0/12"},
+			},
+			kubernetesVersion: "v1.24.0",
+			expectErrs:        0,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := tc.config.Validate(tc.kubernetesVersion)
+			if len(errs) != tc.expectErrs {
+				t.Errorf("expected %d errors, got %d: %v", tc.expectErrs, len(errs), errs)
+			}
+		})
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	cases := []struct {
+		version, min string
+		expect       bool
+	}{
+		{"v1.24.0", "1.24", true},
+		{"1.24.3", "1.24", true},
+		{"v1.23.9", "1.24", false},
+		{"v1.25.0", "1.24", true},
+		{"not-a-version", "1.24", false},
+	}
+	for _, tc := range cases {
+		if got := versionAtLeast(tc.version, tc.min); got != tc.expect {
+			t.Errorf("versionAtLeast(%q, %q) = %v, want %v", tc.version, tc.min, got, tc.expect)
+		}
+	}
+}
+
+func TestNodeToInternalKubeadmConfig(t *testing.T) {
+	n := &Node{
+		KubeadmConfig: &KubeadmConfig{
+			FeatureGates: map[string]bool{"TTLAfterFinished": true},
+		},
+	}
+	if _, err := n.ToInternalKubeadmConfig("v1.21.0"); err == nil {
+		t.Errorf("expected an error for a removed feature gate, got nil")
+	}
+	n.KubeadmConfig.FeatureGates = nil
+	cfg, err := n.ToInternalKubeadmConfig("v1.21.0")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if cfg != n.KubeadmConfig {
+		t.Errorf("expected the validated KubeadmConfig to be returned unchanged")
+	}
+	n2 := &Node{}
+	if cfg, err := n2.ToInternalKubeadmConfig("v1.21.0"); cfg != nil || err != nil {
+		t.Errorf("expected (nil, nil) for a Node with no KubeadmConfig, got (%v, %v)", cfg, err)
+	}
+}
+
+func TestFileSourceValidate(t *testing.T) {
+	cases := []struct {
+		name       string
+		file       FileSource
+		expectErrs int
+	}{
+		{
+			name:       "content only",
+			file:       FileSource{Path: "/etc/kubernetes/audit-policy.yaml", Content: "foo"},
+			expectErrs: 0,
+		},
+		{
+			name:       "contentFrom only",
+			file:       FileSource{Path: "/etc/kubernetes/audit-policy.yaml", ContentFrom: &FileSourceRef{URL: "https://example.com/x"}},
+			expectErrs: 0,
+		},
+		{
+			name:       "neither content nor contentFrom",
+			file:       FileSource{Path: "/etc/kubernetes/audit-policy.yaml"},
+			expectErrs: 1,
+		},
+		{
+			name: "both content and contentFrom",
+			file: FileSource{
+				Path:        "/etc/kubernetes/audit-policy.yaml",
+				Content:     "foo",
+				ContentFrom: &FileSourceRef{URL: "https://example.com/x"},
+			},
+			expectErrs: 1,
+		},
+		{
+			name:       "missing path",
+			file:       FileSource{Content: "foo"},
+			expectErrs: 1,
+		},
+		{
+			name:       "unknown encoding",
+			file:       FileSource{Path: "/x", Content: "foo", Encoding: "rot13"},
+			expectErrs: 1,
+		},
+		{
+			name:       "known encoding",
+			file:       FileSource{Path: "/x", Content: "foo", Encoding: GzipBase64FileEncoding},
+			expectErrs: 0,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := tc.file.Validate()
+			if len(errs) != tc.expectErrs {
+				t.Errorf("expected %d errors, got %d: %v", tc.expectErrs, len(errs), errs)
+			}
+		})
+	}
+}